@@ -0,0 +1,365 @@
+/*
+Package timed_cache implements cache where items are stored for a certain period of time
+
+BSD 2-Clause License
+
+Copyright (c) 2021, Piotr Pszczółkowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+// Package timed_cache (v2) is a generics-based rewrite of the original
+// timed-cache package. It provides the same timed/LRU cache semantics
+// but with typed keys and values, removing the need for callers and the
+// cache itself to perform runtime type assertions.
+package timed_cache
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// NoEvictionTTL is a sentinel TTL that, when passed to AddWithTTL, marks an
+// entry as practically never expiring (mirrors the 10-year default used by
+// similar libraries such as go-pkgz/expirable-cache).
+const NoEvictionTTL = 10 * 365 * 24 * time.Hour
+
+// EvictCallback is used to get a callback when a cache entry is evicted
+type EvictCallback[K comparable, V any] func(key K, value V)
+
+// TimedCache implements a generic timed cache
+type TimedCache[K comparable, V any] struct {
+	sync.Mutex
+	evictList   *list.List
+	items       map[K]*list.Element
+	duration    time.Duration
+	maxKeys     int
+	policyKind  PolicyKind
+	policy      policy[K]
+	onEvictCall EvictCallback[K, V]
+	janitor     *janitor
+	invalidator *invalidator
+	// stats is heap-allocated on its own so its 64-bit counters get the
+	// 8-byte alignment sync/atomic requires on 32-bit platforms; a nested
+	// struct field's offset within TimedCache is not guaranteed aligned.
+	stats *stats
+}
+
+type entry[K comparable, V any] struct {
+	expiresAt time.Time
+	key       K
+	value     V
+}
+
+// NewTimedCache constructs a TimedCache whose entries expire after duration
+// unless overridden per-entry via AddWithTTL. The cache is unbounded in size;
+// use NewTimedCacheWithSize to also bound it by key count.
+func NewTimedCache[K comparable, V any](duration time.Duration, onEvictCall EvictCallback[K, V]) *TimedCache[K, V] {
+	return NewTimedCacheWithSize(0, duration, onEvictCall)
+}
+
+// NewTimedCacheWithSize constructs a TimedCache whose entries expire after
+// duration and which additionally never holds more than maxKeys entries:
+// once full, Add evicts the least recently used entry to make room. A
+// maxKeys of 0 means unlimited, matching NewTimedCache.
+func NewTimedCacheWithSize[K comparable, V any](maxKeys int, duration time.Duration, onEvictCall EvictCallback[K, V]) *TimedCache[K, V] {
+	return NewTimedCacheWithPolicy(maxKeys, duration, PolicyLRU, onEvictCall)
+}
+
+// NewTimedCacheWithPolicy constructs a bounded TimedCache like
+// NewTimedCacheWithSize, but lets the caller choose the eviction policy used
+// to pick a victim once the cache is full. See PolicyLRU, PolicySIEVE and
+// Policy2Q.
+func NewTimedCacheWithPolicy[K comparable, V any](maxKeys int, duration time.Duration, kind PolicyKind, onEvictCall EvictCallback[K, V]) *TimedCache[K, V] {
+	c := &TimedCache[K, V]{
+		evictList:   list.New(),
+		items:       make(map[K]*list.Element),
+		duration:    duration,
+		maxKeys:     maxKeys,
+		policyKind:  kind,
+		policy:      newPolicy[K](kind, maxKeys),
+		onEvictCall: onEvictCall,
+		stats:       &stats{},
+	}
+	return c
+}
+
+// Purge is used to completely clear the cache.
+func (c *TimedCache[K, V]) Purge() {
+	c.Lock()
+	defer c.Unlock()
+
+	for k, v := range c.items {
+		if c.onEvictCall != nil {
+			c.onEvictCall(k, v.Value.(*entry[K, V]).value)
+		}
+		delete(c.items, k)
+	}
+	c.evictList.Init()
+	c.policy = newPolicy[K](c.policyKind, c.maxKeys)
+	atomic.StoreInt64(&c.stats.size, 0)
+}
+
+// Add adds a value to the cache using the cache-wide default TTL. Returns
+// true if an eviction occurred.
+func (c *TimedCache[K, V]) Add(key K, value V) bool {
+	return c.AddWithTTL(key, value, c.duration)
+}
+
+// AddWithTTL adds a value to the cache with a per-entry TTL overriding the
+// cache-wide default. Returns true if an eviction occurred.
+func (c *TimedCache[K, V]) AddWithTTL(key K, value V, ttl time.Duration) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	c.purgeExpired()
+
+	if _, ok := c.items[key]; ok {
+		return false
+	}
+
+	evicted := false
+	if c.maxKeys > 0 && len(c.items) >= c.maxKeys {
+		if victim, ok := c.policy.Evict(); ok {
+			if element, ok := c.items[victim]; ok {
+				// Evict already retired victim from the policy's own
+				// bookkeeping (e.g. 2Q transfers it to the ghost list for
+				// future promotion), so don't call policy.Remove again,
+				// which would undo that.
+				c.removeElementWithPolicy(element, false)
+			}
+			evicted = true
+			atomic.AddUint64(&c.stats.lruEvictions, 1)
+		}
+	}
+
+	ent := &entry[K, V]{time.Now().Add(ttl), key, value}
+	element := c.evictList.PushFront(ent)
+	c.items[key] = element
+	c.policy.Add(key)
+	atomic.AddUint64(&c.stats.adds, 1)
+	atomic.AddInt64(&c.stats.size, 1)
+	return evicted
+}
+
+// Update replaces the value of a key already in the cache, marks it as
+// recently used and resets its expiration to the cache-wide default TTL.
+// Returns true if the key was present; a missing key is not added.
+func (c *TimedCache[K, V]) Update(key K, value V) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	c.purgeExpired()
+
+	if ent, ok := c.items[key]; ok {
+		c.policy.Access(key)
+		c.evictList.MoveToFront(ent)
+		ent.Value.(*entry[K, V]).value = value
+		ent.Value.(*entry[K, V]).expiresAt = time.Now().Add(c.duration)
+		atomic.AddUint64(&c.stats.updates, 1)
+		return true
+	}
+	return false
+}
+
+// Get looks up a key's value from the cache.
+func (c *TimedCache[K, V]) Get(key K) (value V, ok bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.purgeExpired()
+
+	if ent, found := c.items[key]; found {
+		c.policy.Access(key)
+		// SIEVE records a hit only through its visited bit, so the entry
+		// keeps its insertion position.
+		if c.policyKind != PolicySIEVE {
+			c.evictList.MoveToFront(ent)
+		}
+		atomic.AddUint64(&c.stats.hits, 1)
+		return ent.Value.(*entry[K, V]).value, true
+	}
+	atomic.AddUint64(&c.stats.misses, 1)
+	return value, false
+}
+
+// TTL returns the time remaining until key expires.
+func (c *TimedCache[K, V]) TTL(key K) (time.Duration, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.purgeExpired()
+
+	if ent, ok := c.items[key]; ok {
+		return time.Until(ent.Value.(*entry[K, V]).expiresAt), true
+	}
+	return 0, false
+}
+
+// Expiration returns the absolute time at which key expires.
+func (c *TimedCache[K, V]) Expiration(key K) (time.Time, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.purgeExpired()
+
+	if ent, ok := c.items[key]; ok {
+		return ent.Value.(*entry[K, V]).expiresAt, true
+	}
+	return time.Time{}, false
+}
+
+// Contains checks if a key is in the cache, without updating the recent-ness
+// of the key. Expired entries are purged before the check.
+func (c *TimedCache[K, V]) Contains(key K) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	c.purgeExpired()
+
+	_, ok := c.items[key]
+	if ok {
+		atomic.AddUint64(&c.stats.hits, 1)
+	} else {
+		atomic.AddUint64(&c.stats.misses, 1)
+	}
+	return ok
+}
+
+// Peek returns the key value (or the zero value if not found) without updating
+// the "recently used"-ness of the key.
+func (c *TimedCache[K, V]) Peek(key K) (value V, ok bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.purgeExpired()
+
+	if ent, found := c.items[key]; found {
+		atomic.AddUint64(&c.stats.hits, 1)
+		return ent.Value.(*entry[K, V]).value, true
+	}
+	atomic.AddUint64(&c.stats.misses, 1)
+	return value, false
+}
+
+// Remove removes the provided key from the cache
+func (c *TimedCache[K, V]) Remove(key K) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	c.purgeExpired()
+
+	if ent, ok := c.items[key]; ok {
+		c.removeElement(ent)
+		return true
+	}
+	return false
+}
+
+// Keys returns a slice of the keys in the cache, from oldest to newest.
+func (c *TimedCache[K, V]) Keys() []K {
+	c.Lock()
+	defer c.Unlock()
+
+	c.purgeExpired()
+
+	keys := make([]K, len(c.items))
+	i := 0
+	for ent := c.evictList.Back(); ent != nil; ent = ent.Prev() {
+		keys[i] = ent.Value.(*entry[K, V]).key
+		i++
+	}
+	return keys
+}
+
+// Values returns a slice of the values in the cache, from oldest to newest.
+func (c *TimedCache[K, V]) Values() []V {
+	c.Lock()
+	defer c.Unlock()
+
+	c.purgeExpired()
+
+	values := make([]V, len(c.items))
+	i := 0
+	for ent := c.evictList.Back(); ent != nil; ent = ent.Prev() {
+		values[i] = ent.Value.(*entry[K, V]).value
+		i++
+	}
+	return values
+}
+
+// Len returns the number of items in the cache.
+func (c *TimedCache[K, V]) Len() int {
+	c.Lock()
+	defer c.Unlock()
+
+	c.purgeExpired()
+	return c.evictList.Len()
+}
+
+// removeElement is used to remove a given timed-cache element from the cache
+func (c *TimedCache[K, V]) removeElement(e *list.Element) {
+	c.removeElementWithPolicy(e, true)
+}
+
+// removeElementWithPolicy removes e from the cache's own storage, and also
+// tells the policy to forget the key when notifyPolicy is true. Pass false
+// when the policy already retired the key as part of its own Evict (so
+// calling policy.Remove would undo policy-internal bookkeeping, such as 2Q's
+// ghost-list entry for a just-evicted key).
+func (c *TimedCache[K, V]) removeElementWithPolicy(e *list.Element, notifyPolicy bool) {
+	c.evictList.Remove(e)
+	kv := e.Value.(*entry[K, V])
+	delete(c.items, kv.key)
+	if notifyPolicy {
+		c.policy.Remove(kv.key)
+	}
+	atomic.AddInt64(&c.stats.size, -1)
+	if c.onEvictCall != nil {
+		c.onEvictCall(kv.key, kv.value)
+	}
+}
+
+// PurgeExpired removes all the expired entries from the cache.
+func (c *TimedCache[K, V]) PurgeExpired() {
+	c.Lock()
+	defer c.Unlock()
+	c.purgeExpired()
+}
+
+func (c *TimedCache[K, V]) purgeExpired() {
+	now := time.Now()
+
+	// Per-entry TTLs mean expiresAt is no longer monotonic along the
+	// recency list, so every entry must be checked.
+	for e := c.evictList.Back(); e != nil; {
+		prev := e.Prev()
+		if ent := e.Value.(*entry[K, V]); now.After(ent.expiresAt) {
+			c.removeElement(e)
+			atomic.AddUint64(&c.stats.expirationEvictions, 1)
+		}
+		e = prev
+	}
+}
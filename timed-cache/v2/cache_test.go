@@ -0,0 +1,97 @@
+package timed_cache
+
+import (
+	"testing"
+	"time"
+)
+
+// TestKeysReflectsLRURecency guards against evictList and the LRU policy
+// diverging: Keys/Values must report the same recency order the policy
+// uses to pick an eviction victim.
+func TestKeysReflectsLRURecency(t *testing.T) {
+	c := NewTimedCacheWithSize[int, string](3, time.Minute, nil)
+
+	c.Add(1, "a")
+	c.Add(2, "b")
+	c.Add(3, "c")
+
+	if got := c.Keys(); !equalKeys(got, []int{1, 2, 3}) {
+		t.Fatalf("Keys() before Get = %v, want [1 2 3]", got)
+	}
+
+	if _, ok := c.Get(1); !ok {
+		t.Fatalf("Get(1) = not found, want found")
+	}
+
+	// 1 is now most recently used, so it should have moved to the front
+	// (newest) end of Keys(), matching the order the LRU policy would
+	// evict from.
+	if got := c.Keys(); !equalKeys(got, []int{2, 3, 1}) {
+		t.Fatalf("Keys() after Get(1) = %v, want [2 3 1]", got)
+	}
+
+	c.Add(4, "d")
+
+	if c.Contains(2) {
+		t.Fatalf("key 2 should have been evicted as least recently used, but is still present")
+	}
+	if got := c.Keys(); !equalKeys(got, []int{3, 1, 4}) {
+		t.Fatalf("Keys() after eviction = %v, want [3 1 4]", got)
+	}
+}
+
+// TestAddWithTTLOverridesDefault checks that AddWithTTL's ttl argument, not
+// the cache-wide duration, governs TTL()/Expiration() for that entry.
+func TestAddWithTTLOverridesDefault(t *testing.T) {
+	c := NewTimedCache[string, int](time.Hour, nil)
+
+	c.AddWithTTL("short", 1, time.Minute)
+
+	ttl, ok := c.TTL("short")
+	if !ok {
+		t.Fatalf("TTL(\"short\") = not found, want found")
+	}
+	if ttl <= 0 || ttl > time.Minute {
+		t.Fatalf("TTL(\"short\") = %v, want a positive duration <= 1m", ttl)
+	}
+
+	expiresAt, ok := c.Expiration("short")
+	if !ok {
+		t.Fatalf("Expiration(\"short\") = not found, want found")
+	}
+	if time.Until(expiresAt) > time.Minute {
+		t.Fatalf("Expiration(\"short\") is %v out, want <= 1m", time.Until(expiresAt))
+	}
+}
+
+// TestAddReturnsTrueOnlyOnEviction checks Add's documented contract: it
+// returns true only when adding the key evicted another one to make room,
+// not merely because an insert happened.
+func TestAddReturnsTrueOnlyOnEviction(t *testing.T) {
+	c := NewTimedCacheWithSize[int, string](2, time.Hour, nil)
+
+	if c.Add(1, "a") {
+		t.Fatalf("Add(1) on an empty cache reported an eviction")
+	}
+	if c.Add(2, "b") {
+		t.Fatalf("Add(2) below capacity reported an eviction")
+	}
+	if !c.Add(3, "c") {
+		t.Fatalf("Add(3) at capacity should report an eviction")
+	}
+	if c.Contains(1) {
+		t.Fatalf("key 1 should have been evicted to make room for key 3")
+	}
+}
+
+func equalKeys(got, want []int) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package timed_cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Invalidation describes an external cache-coherence event: either a single
+// key to drop or a flush-all signal. It lets a TimedCache stay coherent with
+// other processes sharing the same data, by wiring external notifications
+// (e.g. Postgres LISTEN/NOTIFY, Redis pub/sub, NATS) to local eviction.
+type Invalidation[K comparable] struct {
+	Key      K
+	FlushAll bool
+}
+
+// InvalidateKey builds an Invalidation that drops a single key.
+func InvalidateKey[K comparable](key K) Invalidation[K] {
+	return Invalidation[K]{Key: key}
+}
+
+// InvalidateAll builds an Invalidation that flushes the whole cache.
+func InvalidateAll[K comparable]() Invalidation[K] {
+	return Invalidation[K]{FlushAll: true}
+}
+
+// invalidator drains an Invalidation channel in the background until it is
+// closed or the cache's Close/Stop is called.
+type invalidator struct {
+	stop chan struct{}
+	once sync.Once
+}
+
+// NewTimedCacheWithInvalidation constructs a TimedCache like NewTimedCache,
+// plus a background goroutine that consumes invalidations and removes the
+// matching entries (firing the eviction callback). The goroutine exits when
+// invalidations is closed, or when Close/Stop is called.
+func NewTimedCacheWithInvalidation[K comparable, V any](duration time.Duration, invalidations <-chan Invalidation[K], onEvictCall EvictCallback[K, V]) *TimedCache[K, V] {
+	c := NewTimedCache(duration, onEvictCall)
+	c.invalidator = &invalidator{stop: make(chan struct{})}
+	go runInvalidator(c.invalidator, c, invalidations)
+	return c
+}
+
+func runInvalidator[K comparable, V any](inv *invalidator, c *TimedCache[K, V], invalidations <-chan Invalidation[K]) {
+	for {
+		select {
+		case iv, ok := <-invalidations:
+			if !ok {
+				return
+			}
+			if iv.FlushAll {
+				c.Purge()
+			} else {
+				c.Invalidate(iv.Key)
+			}
+		case <-inv.stop:
+			return
+		}
+	}
+}
+
+// Invalidate removes the given keys from the cache, firing the eviction
+// callback for each one present.
+func (c *TimedCache[K, V]) Invalidate(keys ...K) {
+	for _, key := range keys {
+		c.Remove(key)
+	}
+}
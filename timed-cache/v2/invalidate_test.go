@@ -0,0 +1,73 @@
+package timed_cache
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestInvalidateRemovesKeys checks that Invalidate removes exactly the
+// given keys and fires the eviction callback for each one present.
+func TestInvalidateRemovesKeys(t *testing.T) {
+	var evicted []int
+	c := NewTimedCache[int, string](time.Hour, func(key int, value string) {
+		evicted = append(evicted, key)
+	})
+
+	c.Add(1, "a")
+	c.Add(2, "b")
+	c.Add(3, "c")
+
+	c.Invalidate(1, 3, 99) // 99 isn't present; should be a no-op for it
+
+	if c.Contains(1) || c.Contains(3) {
+		t.Fatalf("keys 1 and 3 should have been invalidated")
+	}
+	if !c.Contains(2) {
+		t.Fatalf("key 2 should still be present")
+	}
+	if len(evicted) != 2 {
+		t.Fatalf("evict callback fired %d times, want 2 (got %v)", len(evicted), evicted)
+	}
+}
+
+// TestInvalidationChannelFlushAll checks that a FlushAll Invalidation sent
+// over the channel empties the cache and fires the eviction callback for
+// every entry, and that a keyed Invalidation removes just that key.
+func TestInvalidationChannelFlushAll(t *testing.T) {
+	var evictedCount int32
+	invalidations := make(chan Invalidation[int], 1)
+
+	c := NewTimedCacheWithInvalidation[int, string](time.Hour, invalidations, func(key int, value string) {
+		atomic.AddInt32(&evictedCount, 1)
+	})
+	defer c.Close()
+
+	c.Add(1, "a")
+	c.Add(2, "b")
+
+	invalidations <- InvalidateKey[int](1)
+	waitUntil(t, func() bool { return !c.Contains(1) })
+	if !c.Contains(2) {
+		t.Fatalf("key 2 should still be present after invalidating key 1")
+	}
+
+	invalidations <- InvalidateAll[int]()
+	waitUntil(t, func() bool { return c.Len() == 0 })
+
+	if atomic.LoadInt32(&evictedCount) != 2 {
+		t.Fatalf("evict callback fired %d times, want 2", atomic.LoadInt32(&evictedCount))
+	}
+}
+
+func waitUntil(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("condition not met within deadline")
+}
@@ -0,0 +1,65 @@
+package timed_cache
+
+import (
+	"sync"
+	"time"
+)
+
+// janitor periodically purges expired entries from a TimedCache in the
+// background so long-idle caches don't keep dead entries (and the values
+// they pin) alive until the next read or write.
+type janitor struct {
+	interval time.Duration
+	stop     chan struct{}
+	once     sync.Once
+}
+
+// NewTimedCacheWithJanitor constructs a TimedCache identical to
+// NewTimedCache, plus a background goroutine that calls PurgeExpired every
+// interval. Call Close (or Stop) to terminate the goroutine and release the
+// cache's entries.
+func NewTimedCacheWithJanitor[K comparable, V any](duration time.Duration, interval time.Duration, onEvictCall EvictCallback[K, V]) *TimedCache[K, V] {
+	c := NewTimedCache(duration, onEvictCall)
+	c.janitor = &janitor{
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+	go c.janitor.run(c)
+	return c
+}
+
+func (j *janitor) run(c interface{ PurgeExpired() }) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.PurgeExpired()
+		case <-j.stop:
+			return
+		}
+	}
+}
+
+// Close terminates the janitor and invalidator goroutines, if running, and
+// purges the cache. It is safe to call Close more than once.
+func (c *TimedCache[K, V]) Close() {
+	c.Stop()
+	c.Purge()
+}
+
+// Stop terminates the janitor and invalidator goroutines, if running,
+// without purging the cache. It is safe to call Stop more than once.
+func (c *TimedCache[K, V]) Stop() {
+	if c.janitor != nil {
+		c.janitor.once.Do(func() {
+			close(c.janitor.stop)
+		})
+	}
+	if c.invalidator != nil {
+		c.invalidator.once.Do(func() {
+			close(c.invalidator.stop)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package timed_cache
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestJanitorPurgesOnTick checks that NewTimedCacheWithJanitor's background
+// goroutine actually purges expired entries (firing the eviction callback)
+// without any caller-driven Get/Add/PurgeExpired call.
+func TestJanitorPurgesOnTick(t *testing.T) {
+	var evicted int32
+	c := NewTimedCacheWithJanitor[string, int](20*time.Millisecond, 10*time.Millisecond, func(key string, value int) {
+		atomic.AddInt32(&evicted, 1)
+	})
+	defer c.Close()
+
+	c.Add("k", 1)
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if atomic.LoadInt32(&evicted) > 0 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if atomic.LoadInt32(&evicted) == 0 {
+		t.Fatalf("janitor did not purge the expired entry within the deadline")
+	}
+	c.Lock()
+	_, stillThere := c.items["k"]
+	c.Unlock()
+	if stillThere {
+		t.Fatalf("expired key should have been removed from the cache by the janitor")
+	}
+}
+
+// TestStopIsIdempotent checks that Stop (and Close, which calls it) can be
+// called more than once without panicking.
+func TestStopIsIdempotent(t *testing.T) {
+	c := NewTimedCacheWithJanitor[string, int](time.Hour, time.Hour, nil)
+
+	c.Stop()
+	c.Stop()
+	c.Close()
+	c.Close()
+}
@@ -0,0 +1,250 @@
+package timed_cache
+
+import "container/list"
+
+// PolicyKind selects which eviction policy a bounded TimedCache uses to pick
+// a victim when it is full. See NewTimedCacheWithPolicy.
+type PolicyKind int
+
+const (
+	// PolicyLRU evicts the least recently used key. This is the default
+	// used by NewTimedCacheWithSize.
+	PolicyLRU PolicyKind = iota
+	// PolicySIEVE evicts using the SIEVE algorithm: a single FIFO queue
+	// with a "visited" bit per key and a hand that sweeps from the tail,
+	// clearing visited bits until it finds an unvisited key to evict.
+	// Get only sets the visited bit, so a hit needs no list mutation.
+	PolicySIEVE
+	// Policy2Q evicts using the 2Q algorithm: new keys enter a "recent"
+	// FIFO queue, which has no size bound of its own and is always evicted
+	// from first while it is non-empty; a key accessed a second time is promoted to a
+	// "frequent" LRU queue. A ghost list remembers keys recently evicted
+	// from the recent queue so they are promoted directly on re-insert.
+	Policy2Q
+)
+
+// policy decides, for a bounded cache, which key to evict next. Get and
+// Update call Access on a hit, Add/AddWithTTL call Add on insert, Remove
+// (plus expiration) call Remove, and Purge replaces the policy with a fresh
+// one. Implementations are not safe for
+// concurrent use; TimedCache serializes access with its own mutex.
+type policy[K comparable] interface {
+	Add(key K)
+	Access(key K)
+	Remove(key K)
+	// Evict picks and forgets a victim key. ok is false if the policy has
+	// nothing left to evict.
+	Evict() (key K, ok bool)
+}
+
+func newPolicy[K comparable](kind PolicyKind, capacity int) policy[K] {
+	switch kind {
+	case PolicySIEVE:
+		return newSievePolicy[K]()
+	case Policy2Q:
+		return newTwoQPolicy[K](capacity)
+	default:
+		return newLRUPolicy[K]()
+	}
+}
+
+// lruPolicy evicts the least recently used key.
+type lruPolicy[K comparable] struct {
+	order *list.List
+	nodes map[K]*list.Element
+}
+
+func newLRUPolicy[K comparable]() *lruPolicy[K] {
+	return &lruPolicy[K]{
+		order: list.New(),
+		nodes: make(map[K]*list.Element),
+	}
+}
+
+func (p *lruPolicy[K]) Add(key K) {
+	p.nodes[key] = p.order.PushFront(key)
+}
+
+func (p *lruPolicy[K]) Access(key K) {
+	if node, ok := p.nodes[key]; ok {
+		p.order.MoveToFront(node)
+	}
+}
+
+func (p *lruPolicy[K]) Remove(key K) {
+	if node, ok := p.nodes[key]; ok {
+		p.order.Remove(node)
+		delete(p.nodes, key)
+	}
+}
+
+func (p *lruPolicy[K]) Evict() (key K, ok bool) {
+	back := p.order.Back()
+	if back == nil {
+		return key, false
+	}
+	key = back.Value.(K)
+	p.order.Remove(back)
+	delete(p.nodes, key)
+	return key, true
+}
+
+// sieveNode is a SIEVE queue entry: a key plus its visited bit.
+type sieveNode[K comparable] struct {
+	key     K
+	visited bool
+}
+
+// sievePolicy implements the SIEVE eviction algorithm: https://sieve-cache.com
+type sievePolicy[K comparable] struct {
+	queue *list.List
+	nodes map[K]*list.Element
+	hand  *list.Element
+}
+
+func newSievePolicy[K comparable]() *sievePolicy[K] {
+	return &sievePolicy[K]{
+		queue: list.New(),
+		nodes: make(map[K]*list.Element),
+	}
+}
+
+func (p *sievePolicy[K]) Add(key K) {
+	p.nodes[key] = p.queue.PushFront(&sieveNode[K]{key: key})
+}
+
+func (p *sievePolicy[K]) Access(key K) {
+	if node, ok := p.nodes[key]; ok {
+		node.Value.(*sieveNode[K]).visited = true
+	}
+}
+
+func (p *sievePolicy[K]) Remove(key K) {
+	node, ok := p.nodes[key]
+	if !ok {
+		return
+	}
+	if p.hand == node {
+		p.hand = p.hand.Prev()
+	}
+	p.queue.Remove(node)
+	delete(p.nodes, key)
+}
+
+func (p *sievePolicy[K]) Evict() (key K, ok bool) {
+	hand := p.hand
+	if hand == nil {
+		hand = p.queue.Back()
+	}
+	for hand != nil {
+		node := hand.Value.(*sieveNode[K])
+		if !node.visited {
+			break
+		}
+		node.visited = false
+		hand = hand.Prev()
+		if hand == nil {
+			hand = p.queue.Back()
+		}
+	}
+	if hand == nil {
+		return key, false
+	}
+	key = hand.Value.(*sieveNode[K]).key
+	p.hand = hand.Prev()
+	p.queue.Remove(hand)
+	delete(p.nodes, key)
+	return key, true
+}
+
+// twoQPolicy implements a simplified 2Q: a FIFO "recent" queue for
+// first-time inserts, an LRU "frequent" queue for keys promoted on a second
+// access, and a ghost queue of recently-evicted recent keys used to promote
+// re-inserted keys straight to frequent.
+type twoQPolicy[K comparable] struct {
+	recent        *list.List
+	recentNodes   map[K]*list.Element
+	frequent      *list.List
+	frequentNodes map[K]*list.Element
+	ghost         *list.List
+	ghostNodes    map[K]*list.Element
+	ghostCap      int
+}
+
+func newTwoQPolicy[K comparable](capacity int) *twoQPolicy[K] {
+	ghostCap := capacity / 2
+	if ghostCap < 1 {
+		ghostCap = 1
+	}
+	return &twoQPolicy[K]{
+		recent:        list.New(),
+		recentNodes:   make(map[K]*list.Element),
+		frequent:      list.New(),
+		frequentNodes: make(map[K]*list.Element),
+		ghost:         list.New(),
+		ghostNodes:    make(map[K]*list.Element),
+		ghostCap:      ghostCap,
+	}
+}
+
+func (p *twoQPolicy[K]) Add(key K) {
+	if node, ok := p.ghostNodes[key]; ok {
+		p.ghost.Remove(node)
+		delete(p.ghostNodes, key)
+		p.frequentNodes[key] = p.frequent.PushFront(key)
+		return
+	}
+	p.recentNodes[key] = p.recent.PushFront(key)
+}
+
+func (p *twoQPolicy[K]) Access(key K) {
+	if node, ok := p.recentNodes[key]; ok {
+		p.recent.Remove(node)
+		delete(p.recentNodes, key)
+		p.frequentNodes[key] = p.frequent.PushFront(key)
+		return
+	}
+	if node, ok := p.frequentNodes[key]; ok {
+		p.frequent.MoveToFront(node)
+	}
+}
+
+func (p *twoQPolicy[K]) Remove(key K) {
+	if node, ok := p.recentNodes[key]; ok {
+		p.recent.Remove(node)
+		delete(p.recentNodes, key)
+		return
+	}
+	if node, ok := p.frequentNodes[key]; ok {
+		p.frequent.Remove(node)
+		delete(p.frequentNodes, key)
+		return
+	}
+	if node, ok := p.ghostNodes[key]; ok {
+		p.ghost.Remove(node)
+		delete(p.ghostNodes, key)
+	}
+}
+
+func (p *twoQPolicy[K]) Evict() (key K, ok bool) {
+	if back := p.recent.Back(); back != nil {
+		key = back.Value.(K)
+		p.recent.Remove(back)
+		delete(p.recentNodes, key)
+
+		p.ghostNodes[key] = p.ghost.PushFront(key)
+		for p.ghost.Len() > p.ghostCap {
+			gb := p.ghost.Back()
+			delete(p.ghostNodes, gb.Value.(K))
+			p.ghost.Remove(gb)
+		}
+		return key, true
+	}
+	if back := p.frequent.Back(); back != nil {
+		key = back.Value.(K)
+		p.frequent.Remove(back)
+		delete(p.frequentNodes, key)
+		return key, true
+	}
+	return key, false
+}
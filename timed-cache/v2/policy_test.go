@@ -0,0 +1,55 @@
+package timed_cache
+
+import (
+	"testing"
+	"time"
+)
+
+// TestTwoQPolicyGhostPromotion guards against the ghost list being wiped
+// out by the cache's own eviction path: a key evicted from "recent" must
+// stay in the ghost list (so a later re-Add promotes it straight to
+// "frequent") instead of being immediately forgotten.
+func TestTwoQPolicyGhostPromotion(t *testing.T) {
+	p := newTwoQPolicy[int](2)
+
+	p.Add(1)
+	p.Add(2)
+
+	victim, ok := p.Evict()
+	if !ok || victim != 1 {
+		t.Fatalf("Evict() = (%v, %v), want (1, true)", victim, ok)
+	}
+
+	if _, ok := p.ghostNodes[1]; !ok {
+		t.Fatalf("key 1 should be in the ghost list after eviction, ghostNodes = %v", p.ghostNodes)
+	}
+
+	p.Add(1)
+
+	if _, ok := p.frequentNodes[1]; !ok {
+		t.Fatalf("re-added ghost key 1 should be promoted to frequent, frequentNodes = %v", p.frequentNodes)
+	}
+	if _, ok := p.recentNodes[1]; ok {
+		t.Fatalf("re-added ghost key 1 should not land back in recent, recentNodes = %v", p.recentNodes)
+	}
+}
+
+// TestTwoQPolicyGhostSurvivesCacheEviction exercises the same scenario
+// through the public TimedCache API: evicting a key via Policy2Q must not
+// have removeElement's generic policy.Remove wipe the key back out of the
+// ghost list.
+func TestTwoQPolicyGhostSurvivesCacheEviction(t *testing.T) {
+	c := NewTimedCacheWithPolicy[int, string](2, time.Hour, Policy2Q, nil)
+
+	c.Add(1, "a")
+	c.Add(2, "b")
+	c.Add(3, "c") // evicts 1 (oldest in "recent")
+
+	tq, ok := c.policy.(*twoQPolicy[int])
+	if !ok {
+		t.Fatalf("cache policy is %T, want *twoQPolicy[int]", c.policy)
+	}
+	if _, ok := tq.ghostNodes[1]; !ok {
+		t.Fatalf("key 1 should still be in the ghost list after cache eviction, ghostNodes = %v", tq.ghostNodes)
+	}
+}
@@ -0,0 +1,41 @@
+package timed_cache
+
+import "sync/atomic"
+
+// Stats is a point-in-time snapshot of a TimedCache's counters.
+type Stats struct {
+	Hits                uint64
+	Misses              uint64
+	Adds                uint64
+	Updates             uint64
+	LRUEvictions        uint64
+	ExpirationEvictions uint64
+	Size                int64
+}
+
+// stats holds the atomic counters backing TimedCache.Stats. TimedCache holds
+// it via a pointer, allocated fresh by every constructor, so its 64-bit
+// fields get the 8-byte alignment sync/atomic requires on 32-bit platforms.
+type stats struct {
+	hits                uint64
+	misses              uint64
+	adds                uint64
+	updates             uint64
+	lruEvictions        uint64
+	expirationEvictions uint64
+	size                int64
+}
+
+// Stats returns a snapshot of the cache's counters. It is safe to call
+// concurrently with any other method and does not take the cache's mutex.
+func (c *TimedCache[K, V]) Stats() Stats {
+	return Stats{
+		Hits:                atomic.LoadUint64(&c.stats.hits),
+		Misses:              atomic.LoadUint64(&c.stats.misses),
+		Adds:                atomic.LoadUint64(&c.stats.adds),
+		Updates:             atomic.LoadUint64(&c.stats.updates),
+		LRUEvictions:        atomic.LoadUint64(&c.stats.lruEvictions),
+		ExpirationEvictions: atomic.LoadUint64(&c.stats.expirationEvictions),
+		Size:                atomic.LoadInt64(&c.stats.size),
+	}
+}
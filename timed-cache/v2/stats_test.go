@@ -0,0 +1,66 @@
+package timed_cache
+
+import (
+	"testing"
+	"time"
+)
+
+// TestStatsCounters checks that Stats() counts hits, misses, adds, updates,
+// LRU evictions, and the current size.
+func TestStatsCounters(t *testing.T) {
+	c := NewTimedCacheWithSize[int, string](2, time.Hour, nil)
+
+	c.Add(1, "a")
+	c.Add(2, "b")
+
+	if _, ok := c.Get(1); !ok {
+		t.Fatalf("Get(1) = not found, want found")
+	}
+	if _, ok := c.Get(99); ok {
+		t.Fatalf("Get(99) = found, want not found")
+	}
+
+	c.Update(1, "a2")
+	c.Add(3, "c") // evicts key 2 (least recently used)
+
+	s := c.Stats()
+	if s.Adds != 3 {
+		t.Errorf("Adds = %d, want 3", s.Adds)
+	}
+	if s.Hits != 1 {
+		t.Errorf("Hits = %d, want 1", s.Hits)
+	}
+	if s.Misses != 1 {
+		t.Errorf("Misses = %d, want 1", s.Misses)
+	}
+	if s.Updates != 1 {
+		t.Errorf("Updates = %d, want 1", s.Updates)
+	}
+	if s.LRUEvictions != 1 {
+		t.Errorf("LRUEvictions = %d, want 1", s.LRUEvictions)
+	}
+	if s.Size != 2 {
+		t.Errorf("Size = %d, want 2", s.Size)
+	}
+}
+
+// TestStatsExpirationEvictions checks that an entry timing out is counted
+// as an expiration eviction, not an LRU eviction.
+func TestStatsExpirationEvictions(t *testing.T) {
+	c := NewTimedCache[string, int](10*time.Millisecond, nil)
+
+	c.Add("k", 1)
+	time.Sleep(20 * time.Millisecond)
+	c.PurgeExpired()
+
+	s := c.Stats()
+	if s.ExpirationEvictions != 1 {
+		t.Errorf("ExpirationEvictions = %d, want 1", s.ExpirationEvictions)
+	}
+	if s.LRUEvictions != 0 {
+		t.Errorf("LRUEvictions = %d, want 0", s.LRUEvictions)
+	}
+	if s.Size != 0 {
+		t.Errorf("Size = %d, want 0", s.Size)
+	}
+}